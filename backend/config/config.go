@@ -0,0 +1,53 @@
+// Package config carga la configuración de la aplicación desde variables de
+// entorno, con valores por defecto que reproducen el comportamiento previo
+// (conexión local a "seriesdb" y servidor en :8080).
+package config
+
+import "os"
+
+type Config struct {
+	DBHost     string
+	DBPort     string
+	DBUser     string
+	DBPassword string
+	DBName     string
+	DBSSLMode  string
+	HTTPAddr   string
+	TMDBAPIKey string
+	JWTSecret  string
+}
+
+// Load lee la configuración desde el entorno, aplicando valores por defecto
+// para desarrollo local cuando una variable no está definida. JWTSecret no
+// tiene default: un secreto conocido firmaría tokens falsificables, así que
+// el caller debe exigir que venga seteado.
+func Load() Config {
+	return Config{
+		DBHost:     getEnv("DB_HOST", "db"),
+		DBPort:     getEnv("DB_PORT", "5432"),
+		DBUser:     getEnv("DB_USER", "user"),
+		DBPassword: getEnv("DB_PASSWORD", "password"),
+		DBName:     getEnv("DB_NAME", "seriesdb"),
+		DBSSLMode:  getEnv("DB_SSLMODE", "disable"),
+		HTTPAddr:   getEnv("HTTP_ADDR", ":8080"),
+		TMDBAPIKey: os.Getenv("TMDB_API_KEY"),
+		JWTSecret:  os.Getenv("JWT_SECRET"),
+	}
+}
+
+// DataSourceName arma la cadena de conexión que espera lib/pq.
+func (c Config) DataSourceName() string {
+	return "host=" + c.DBHost +
+		" port=" + c.DBPort +
+		" user=" + c.DBUser +
+		" password=" + c.DBPassword +
+		" dbname=" + c.DBName +
+		" sslmode=" + c.DBSSLMode
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -1,115 +1,443 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"golang.org/x/crypto/bcrypt"
+
+	"Lab6STW/backend/config"
+	_ "Lab6STW/backend/docs"
+	"Lab6STW/backend/providers"
+	"Lab6STW/backend/repository"
 )
 
-type Series struct {
-	ID             int    `json:"id" db:"id"`
-	Title          string `json:"title" db:"title" binding:"required"`
-	Description    string `json:"description" db:"description"`
-	Status         string `json:"status" db:"status"` // pending, watching, completed
-	CurrentEpisode int    `json:"current_episode" db:"current_episode"`
-	TotalEpisodes  int    `json:"total_episodes" db:"total_episodes"`
-	Score          int    `json:"score" db:"score"`
+// Series es un alias del tipo de dominio del repositorio, para que los
+// handlers y las anotaciones de Swagger puedan seguir refiriéndose a
+// "Series" sin importar el paquete repository en cada sitio.
+// @Description Una serie de TV/anime en el watchlist del usuario autenticado
+type Series = repository.Series
+
+type User struct {
+	ID           int       `json:"id" db:"id"`
+	Username     string    `json:"username" db:"username"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
+type authClaims struct {
+	UserID int `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// server agrupa las dependencias que los handlers necesitan, inyectadas por
+// constructor en vez de leídas de c.MustGet("db").
+type server struct {
+	db         *sqlx.DB
+	seriesRepo repository.SeriesRepository
+	providers  map[string]providers.MetadataProvider
+	jwtSecret  []byte
+}
+
+func newServer(db *sqlx.DB, seriesRepo repository.SeriesRepository, metadataProviders map[string]providers.MetadataProvider, jwtSecret []byte) *server {
+	return &server{db: db, seriesRepo: seriesRepo, providers: metadataProviders, jwtSecret: jwtSecret}
+}
+
+func (s *server) routes() *gin.Engine {
+	r := gin.Default()
+
+	// Middleware para CORS
+	r.Use(func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	})
+
+	// Documentación Swagger
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Endpoints de autenticación (públicos)
+	r.POST("/api/auth/register", s.registerUser)
+	r.POST("/api/auth/login", s.loginUser)
+
+	// Endpoints de series (requieren sesión)
+	api := r.Group("/api/series")
+	api.Use(authRequired(s.jwtSecret))
+	{
+		api.GET("", s.getSeries)
+		api.GET("/:id", s.getSeriesByID)
+		api.POST("", s.createSeries)
+		api.PUT("/:id", s.updateSeries)
+		api.DELETE("/:id", s.deleteSeries)
+		api.PATCH("/:id/status", s.updateStatus)
+		api.PATCH("/:id/episode", s.incrementEpisode)
+		api.PATCH("/:id/upvote", s.upvoteSeries)
+		api.PATCH("/:id/downvote", s.downvoteSeries)
+		api.GET("/search", s.searchSeries)
+		api.POST("/import", s.importSeries)
+	}
+
+	return r
+}
+
+// @title Series Watchlist API
+// @version 1.0
+// @description API para gestionar watchlists personales de series, con autenticación JWT.
+// @BasePath /api
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
-	// Configuración de la base de datos
-	db, err := sqlx.Connect("postgres", "user=user dbname=seriesdb password=password host=db sslmode=disable")
+	cfg := config.Load()
+
+	if cfg.JWTSecret == "" {
+		log.Fatal("JWT_SECRET environment variable is required")
+	}
+
+	db, err := sqlx.Connect("postgres", cfg.DataSourceName())
 	if err != nil {
 		log.Fatalf("Error connecting to database: %v", err)
 	}
 	defer db.Close()
 
-	// Crear tablas si no existen
+	if err := migrate(db); err != nil {
+		log.Fatalf("Error creating tables: %v", err)
+	}
+
+	metadataProviders := map[string]providers.MetadataProvider{
+		"tvmaze": providers.NewTVMazeProvider(nil),
+		"tmdb":   providers.NewTMDBProvider(cfg.TMDBAPIKey, nil),
+	}
+
+	srv := newServer(db, repository.NewPostgresSeriesRepository(db), metadataProviders, []byte(cfg.JWTSecret))
+
+	httpServer := &http.Server{
+		Addr:    cfg.HTTPAddr,
+		Handler: srv.routes(),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Println("Shutting down server, draining in-flight requests...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("Error during graceful shutdown: %v", err)
+	}
+}
+
+// migrate crea las tablas que la aplicación necesita si todavía no existen.
+func migrate(db *sqlx.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS users (
+			id SERIAL PRIMARY KEY,
+			username VARCHAR(255) UNIQUE NOT NULL,
+			password_hash VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		return err
+	}
+
 	if _, err := db.Exec(`
 		CREATE TABLE IF NOT EXISTS series (
 			id SERIAL PRIMARY KEY,
+			user_id INTEGER NOT NULL REFERENCES users(id),
 			title VARCHAR(255) NOT NULL,
 			description TEXT,
 			status VARCHAR(50) DEFAULT 'pending',
 			current_episode INTEGER DEFAULT 0,
 			total_episodes INTEGER,
 			score INTEGER DEFAULT 0,
+			thumbnail_url TEXT,
+			air_date VARCHAR(50),
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		);
 	`); err != nil {
-		log.Fatalf("Error creating tables: %v", err)
+		return err
 	}
 
-	r := gin.Default()
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS series_votes (
+			user_id INTEGER NOT NULL REFERENCES users(id),
+			series_id INTEGER NOT NULL REFERENCES series(id),
+			value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+			PRIMARY KEY (user_id, series_id)
+		);
+	`)
+	return err
+}
 
-	// Middleware para CORS
-	r.Use(func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+// authRequired valida el JWT de la cabecera Authorization y deja el userID
+// disponible para los handlers vía c.Set("userID", ...).
+func authRequired(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid Authorization header"})
+			return
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
+		claims := &authClaims{}
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(t *jwt.Token) (interface{}, error) {
+			return secret, nil
+		})
+		if err != nil || !token.Valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			return
 		}
 
+		c.Set("userID", claims.UserID)
 		c.Next()
-	})
+	}
+}
 
-	// Middleware para inyectar la conexión a la base de datos
-	r.Use(func(c *gin.Context) {
-		c.Set("db", db)
-		c.Next()
-	})
+// registerUser godoc
+// @Summary      Register a new user
+// @Description  Creates a user account with a bcrypt-hashed password
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{username=string,password=string} true "Credentials"
+// @Success      201 {object} object{id=int,username=string}
+// @Failure      400 {object} object{error=string}
+// @Failure      409 {object} object{error=string}
+// @Router       /auth/register [post]
+func (s *server) registerUser(c *gin.Context) {
+	var request struct {
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required,min=8"`
+	}
 
-	// Endpoints
-	r.GET("/api/series", getSeries)
-	r.GET("/api/series/:id", getSeriesByID)
-	r.POST("/api/series", createSeries)
-	r.PUT("/api/series/:id", updateSeries)
-	r.DELETE("/api/series/:id", deleteSeries)
-	r.PATCH("/api/series/:id/status", updateStatus)
-	r.PATCH("/api/series/:id/episode", incrementEpisode)
-	r.PATCH("/api/series/:id/upvote", upvoteSeries)
-	r.PATCH("/api/series/:id/downvote", downvoteSeries)
-
-	// Iniciar el servidor
-	if err := r.Run(":8080"); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var id int
+	err = s.db.QueryRow(`
+		INSERT INTO users (username, password_hash)
+		VALUES ($1, $2)
+		RETURNING id
+	`, request.Username, string(hash)).Scan(&id)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already taken"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
+
+	c.JSON(http.StatusCreated, gin.H{"id": id, "username": request.Username})
 }
 
-// Handler para obtener todas las series
-func getSeries(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
+// loginUser godoc
+// @Summary      Log in
+// @Description  Verifies credentials and issues a JWT bearer token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{username=string,password=string} true "Credentials"
+// @Success      200 {object} object{token=string}
+// @Failure      400 {object} object{error=string}
+// @Failure      401 {object} object{error=string}
+// @Router       /auth/login [post]
+func (s *server) loginUser(c *gin.Context) {
+	var request struct {
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user User
+	err := s.db.Get(&user, "SELECT * FROM users WHERE username = $1", request.Username)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		return
+	}
 
-	var series []Series
-	err := db.Select(&series, "SELECT * FROM series")
+	claims := authClaims{
+		UserID: user.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
 
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(s.jwtSecret)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, series)
+	c.JSON(http.StatusOK, gin.H{"token": signed})
 }
 
-// Handler para obtener una serie por ID
-func getSeriesByID(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
+const (
+	defaultLimit = 20
+	maxLimit     = 1000
+)
 
-	var series Series
-	err := db.Get(&series, "SELECT * FROM series WHERE id = $1", id)
+// getSeries godoc
+// @Summary      List series
+// @Description  Lists the authenticated user's watchlist, with pagination, sorting and filters
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit query int false "Max rows to return (default 20, max 1000)"
+// @Param        offset query int false "Rows to skip (default 0)"
+// @Param        sort_column query string false "id, title, score, current_episode or updated_at"
+// @Param        sort_order query string false "asc or desc"
+// @Param        status query string false "pending, watching or completed"
+// @Param        min_score query int false "Minimum score"
+// @Param        title_like query string false "Case-insensitive title substring"
+// @Success      200 {object} object{data=[]Series,total=int,limit=int,offset=int}
+// @Failure      400 {object} object{error=string}
+// @Router       /series [get]
+func (s *server) getSeries(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+
+	limit := defaultLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
 
+	offset := 0
+	if raw := c.Query("offset"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+			return
+		}
+		offset = parsed
+	}
+
+	sortColumn := c.DefaultQuery("sort_column", "id")
+	if !repository.SortColumnWhitelist[sortColumn] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort_column"})
+		return
+	}
+
+	sortOrder := strings.ToUpper(c.DefaultQuery("sort_order", "asc"))
+	if sortOrder != "ASC" && sortOrder != "DESC" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort_order"})
+		return
+	}
+
+	opts := repository.ListOptions{
+		Limit:      limit,
+		Offset:     offset,
+		SortColumn: sortColumn,
+		SortOrder:  sortOrder,
+		Status:     c.Query("status"),
+		TitleLike:  c.Query("title_like"),
+	}
+
+	if raw := c.Query("min_score"); raw != "" {
+		minScore, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_score"})
+			return
+		}
+		opts.MinScore = &minScore
+	}
+
+	series, total, err := s.seriesRepo.List(c.Request.Context(), userID, opts)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   series,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// getSeriesByID godoc
+// @Summary      Get a series
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Success      200 {object} Series
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id} [get]
+func (s *server) getSeriesByID(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series id"})
+		return
+	}
+
+	series, err := s.seriesRepo.Get(c.Request.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
 			return
 		}
@@ -120,9 +448,18 @@ func getSeriesByID(c *gin.Context) {
 	c.JSON(http.StatusOK, series)
 }
 
-// Handler para crear una nueva serie
-func createSeries(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
+// createSeries godoc
+// @Summary      Create a series
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        series body Series true "Series to create"
+// @Success      201 {object} Series
+// @Failure      400 {object} object{error=string}
+// @Router       /series [post]
+func (s *server) createSeries(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
 
 	var newSeries Series
 	if err := c.ShouldBindJSON(&newSeries); err != nil {
@@ -140,28 +477,39 @@ func createSeries(c *gin.Context) {
 	if newSeries.Status == "" {
 		newSeries.Status = "pending"
 	}
+	newSeries.UserID = userID
+	// El score solo lo escribe AdjustScore vía los votos; ignorar cualquier
+	// valor que venga del cliente evita que se manipule al crear.
+	newSeries.Score = 0
 
-	var id int
-	err := db.QueryRow(`
-		INSERT INTO series (title, description, status, current_episode, total_episodes, score)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
-	`, newSeries.Title, newSeries.Description, newSeries.Status,
-		newSeries.CurrentEpisode, newSeries.TotalEpisodes, newSeries.Score).Scan(&id)
-
+	created, err := s.seriesRepo.Create(c.Request.Context(), newSeries)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	newSeries.ID = id
-	c.JSON(http.StatusCreated, newSeries)
+	c.JSON(http.StatusCreated, created)
 }
 
-// Handler para actualizar una serie
-func updateSeries(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
+// updateSeries godoc
+// @Summary      Update a series
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Param        series body Series true "Updated series"
+// @Success      200 {object} object{message=string}
+// @Failure      400 {object} object{error=string}
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id} [put]
+func (s *server) updateSeries(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series id"})
+		return
+	}
 
 	var updateData Series
 	if err := c.ShouldBindJSON(&updateData); err != nil {
@@ -169,23 +517,11 @@ func updateSeries(c *gin.Context) {
 		return
 	}
 
-	// Verificar que la serie existe
-	var exists bool
-	err := db.Get(&exists, "SELECT EXISTS(SELECT 1 FROM series WHERE id = $1)", id)
-	if err != nil || !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
-		return
-	}
-
-	_, err = db.Exec(`
-		UPDATE series 
-		SET title = $1, description = $2, status = $3, 
-			current_episode = $4, total_episodes = $5, score = $6
-		WHERE id = $7
-	`, updateData.Title, updateData.Description, updateData.Status,
-		updateData.CurrentEpisode, updateData.TotalEpisodes, updateData.Score, id)
-
-	if err != nil {
+	if err := s.seriesRepo.Update(c.Request.Context(), id, userID, updateData); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -193,30 +529,54 @@ func updateSeries(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Series updated successfully"})
 }
 
-// Handler para eliminar una serie
-func deleteSeries(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
-
-	result, err := db.Exec("DELETE FROM series WHERE id = $1", id)
+// deleteSeries godoc
+// @Summary      Delete a series
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Success      200 {object} object{message=string}
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id} [delete]
+func (s *server) deleteSeries(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series id"})
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+	if err := s.seriesRepo.Delete(c.Request.Context(), id, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Series deleted successfully"})
 }
 
-// Handler para actualizar el estado de una serie (PATCH)
-func updateStatus(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
+// updateStatus godoc
+// @Summary      Update a series' status
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Param        request body object{status=string} true "New status: pending, watching or completed"
+// @Success      200 {object} object{message=string}
+// @Failure      400 {object} object{error=string}
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id}/status [patch]
+func (s *server) updateStatus(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series id"})
+		return
+	}
 
 	var request struct {
 		Status string `json:"status" binding:"required"`
@@ -239,8 +599,11 @@ func updateStatus(c *gin.Context) {
 		return
 	}
 
-	_, err := db.Exec("UPDATE series SET status = $1 WHERE id = $2", request.Status, id)
-	if err != nil {
+	if err := s.seriesRepo.SetStatus(c.Request.Context(), id, userID, request.Status); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -248,20 +611,81 @@ func updateStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Status updated successfully"})
 }
 
-// Handler para incrementar el episodio (PATCH)
-func incrementEpisode(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
+// incrementEpisode godoc
+// @Summary      Advance to the next episode
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Success      200 {object} object{message=string}
+// @Failure      400 {object} object{error=string}
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id}/episode [patch]
+func (s *server) incrementEpisode(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series id"})
+		return
+	}
+
+	if err := s.seriesRepo.IncrementEpisode(c.Request.Context(), id, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrLastEpisode) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Episode incremented successfully"})
+}
+
+// upvoteSeries godoc
+// @Summary      Upvote a series
+// @Description  Toggles the caller's upvote: repeating it removes the vote, an existing downvote is flipped
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Success      200 {object} object{score=int,my_vote=int}
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id}/upvote [patch]
+func (s *server) upvoteSeries(c *gin.Context) {
+	s.castVote(c, 1)
+}
+
+// downvoteSeries godoc
+// @Summary      Downvote a series
+// @Description  Toggles the caller's downvote: repeating it removes the vote, an existing upvote is flipped
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Series ID"
+// @Success      200 {object} object{score=int,my_vote=int}
+// @Failure      404 {object} object{error=string}
+// @Router       /series/{id}/downvote [patch]
+func (s *server) downvoteSeries(c *gin.Context) {
+	s.castVote(c, -1)
+}
 
-	// Primero obtenemos el episodio actual
-	var current, total int
-	err := db.QueryRow(`
-		SELECT current_episode, total_episodes 
-		FROM series WHERE id = $1
-	`, id).Scan(&current, &total)
+// castVote delega en el repositorio el registro del voto y la recomputación
+// del score agregado.
+func (s *server) castVote(c *gin.Context, value int) {
+	userID := c.MustGet("userID").(int)
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series id"})
+		return
+	}
 
+	score, myVote, err := s.seriesRepo.AdjustScore(c.Request.Context(), id, userID, value)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
 			return
 		}
@@ -269,61 +693,127 @@ func incrementEpisode(c *gin.Context) {
 		return
 	}
 
-	// Verificamos que no exceda el total de episodios
-	if current >= total {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Already at the last episode"})
+	c.JSON(http.StatusOK, gin.H{"score": score, "my_vote": myVote})
+}
+
+// searchSeries godoc
+// @Summary      Search an external catalog
+// @Description  Proxies a search to TVMaze or TMDB without persisting anything, for autocomplete
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        provider query string true "tvmaze or tmdb"
+// @Param        q query string true "Search query"
+// @Success      200 {array} providers.Result
+// @Failure      400 {object} object{error=string}
+// @Failure      502 {object} object{error=string}
+// @Router       /series/search [get]
+func (s *server) searchSeries(c *gin.Context) {
+	provider, err := s.provider(c.Query("provider"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Incrementamos el episodio
-	_, err = db.Exec(`
-		UPDATE series 
-		SET current_episode = current_episode + 1 
-		WHERE id = $1
-	`, id)
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
 
+	results, err := provider.Search(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Episode incremented successfully"})
+	c.JSON(http.StatusOK, results)
 }
 
-// Handler para aumentar puntuación (PATCH)
-func upvoteSeries(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
+// importSeries godoc
+// @Summary      Import a series from an external catalog
+// @Description  Fetches full metadata from TVMaze/TMDB (by query or external id) and inserts it into the caller's watchlist
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{provider=string,query=string,external_id=string} true "Either query or external_id"
+// @Success      201 {object} Series
+// @Failure      400 {object} object{error=string}
+// @Failure      404 {object} object{error=string}
+// @Failure      502 {object} object{error=string}
+// @Router       /series/import [post]
+func (s *server) importSeries(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
 
-	_, err := db.Exec(`
-		UPDATE series 
-		SET score = score + 1 
-		WHERE id = $1
-	`, id)
+	var request struct {
+		Provider   string `json:"provider" binding:"required"`
+		Query      string `json:"query"`
+		ExternalID string `json:"external_id"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
+	provider, err := s.provider(request.Provider)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Score increased successfully"})
-}
+	externalID := request.ExternalID
+	if externalID == "" {
+		if request.Query == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "query or external_id is required"})
+			return
+		}
 
-// Handler para disminuir puntuación (PATCH)
-func downvoteSeries(c *gin.Context) {
-	db := c.MustGet("db").(*sqlx.DB)
-	id := c.Param("id")
+		matches, err := provider.Search(c.Request.Context(), request.Query)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+		if len(matches) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No matches found"})
+			return
+		}
+		externalID = matches[0].ExternalID
+	}
 
-	_, err := db.Exec(`
-		UPDATE series 
-		SET score = score - 1 
-		WHERE id = $1
-	`, id)
+	result, err := provider.Fetch(c.Request.Context(), externalID)
+	if err != nil {
+		if errors.Is(err, providers.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
 
+	created, err := s.seriesRepo.Create(c.Request.Context(), Series{
+		UserID:        userID,
+		Title:         result.Title,
+		Description:   result.Description,
+		Status:        "pending",
+		TotalEpisodes: result.TotalEpisodes,
+		ThumbnailURL:  result.ThumbnailURL,
+		AirDate:       result.AirDate,
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Score decreased successfully"})
+	c.JSON(http.StatusCreated, created)
+}
+
+// provider resuelve el MetadataProvider solicitado por nombre.
+func (s *server) provider(name string) (providers.MetadataProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+	return provider, nil
 }
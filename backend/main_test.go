@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"Lab6STW/backend/repository"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// stubSeriesRepository implementa repository.SeriesRepository con funciones
+// configurables por test, para ejercitar los handlers sin una base de datos.
+type stubSeriesRepository struct {
+	get    func(id, userID int) (repository.Series, error)
+	create func(s repository.Series) (repository.Series, error)
+}
+
+func (r *stubSeriesRepository) List(ctx context.Context, userID int, opts repository.ListOptions) ([]repository.Series, int, error) {
+	return nil, 0, nil
+}
+
+func (r *stubSeriesRepository) Get(ctx context.Context, id, userID int) (repository.Series, error) {
+	return r.get(id, userID)
+}
+
+func (r *stubSeriesRepository) Create(ctx context.Context, s repository.Series) (repository.Series, error) {
+	return r.create(s)
+}
+
+func (r *stubSeriesRepository) Update(ctx context.Context, id, userID int, s repository.Series) error {
+	return nil
+}
+
+func (r *stubSeriesRepository) Delete(ctx context.Context, id, userID int) error {
+	return nil
+}
+
+func (r *stubSeriesRepository) SetStatus(ctx context.Context, id, userID int, status string) error {
+	return nil
+}
+
+func (r *stubSeriesRepository) IncrementEpisode(ctx context.Context, id, userID int) error {
+	return nil
+}
+
+func (r *stubSeriesRepository) AdjustScore(ctx context.Context, id, userID, value int) (int, int, error) {
+	return 0, 0, nil
+}
+
+func TestGetSeriesByID(t *testing.T) {
+	repo := &stubSeriesRepository{
+		get: func(id, userID int) (repository.Series, error) {
+			if id != 7 || userID != 1 {
+				t.Fatalf("unexpected Get call: id=%d userID=%d", id, userID)
+			}
+			return repository.Series{ID: 7, UserID: 1, Title: "Arcane", Score: 3}, nil
+		},
+	}
+	srv := newServer(nil, repo, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/series/7", nil)
+	c.Params = gin.Params{{Key: "id", Value: "7"}}
+	c.Set("userID", 1)
+
+	srv.getSeriesByID(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetSeriesByIDNotFound(t *testing.T) {
+	repo := &stubSeriesRepository{
+		get: func(id, userID int) (repository.Series, error) {
+			return repository.Series{}, repository.ErrNotFound
+		},
+	}
+	srv := newServer(nil, repo, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/series/9", nil)
+	c.Params = gin.Params{{Key: "id", Value: "9"}}
+	c.Set("userID", 1)
+
+	srv.getSeriesByID(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateSeriesIgnoresClientScore(t *testing.T) {
+	var created repository.Series
+	repo := &stubSeriesRepository{
+		create: func(s repository.Series) (repository.Series, error) {
+			created = s
+			s.ID = 1
+			return s, nil
+		},
+	}
+	srv := newServer(nil, repo, nil, nil)
+
+	body := `{"title":"Arcane","score":999}`
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/series", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", 1)
+
+	srv.createSeries(c)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if created.Score != 0 {
+		t.Fatalf("expected client-supplied score to be ignored, got %d", created.Score)
+	}
+}
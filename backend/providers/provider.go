@@ -0,0 +1,29 @@
+// Package providers implementa clientes para catálogos externos (TVMaze,
+// TMDB) usados para auto-rellenar los metadatos de una serie al importarla.
+package providers
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound indica que el proveedor no encontró el id solicitado.
+var ErrNotFound = errors.New("external series not found")
+
+// Result es la representación común de una serie tal como la devuelve un
+// proveedor externo, antes de convertirse en un repository.Series.
+type Result struct {
+	ExternalID    string `json:"external_id"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	TotalEpisodes int    `json:"total_episodes"`
+	ThumbnailURL  string `json:"thumbnail_url"`
+	AirDate       string `json:"air_date"`
+}
+
+// MetadataProvider abstrae un catálogo externo de series para que importar
+// y buscar no dependan de TVMaze o TMDB directamente.
+type MetadataProvider interface {
+	Search(ctx context.Context, query string) ([]Result, error)
+	Fetch(ctx context.Context, externalID string) (Result, error)
+}
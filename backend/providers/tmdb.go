@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const tmdbBaseURL = "https://api.themoviedb.org/3"
+const tmdbImageBaseURL = "https://image.tmdb.org/t/p/original"
+
+// TMDBProvider busca y obtiene series en el catálogo de The Movie Database.
+// Requiere una API key, pasada por el caller (ver config.Config.TMDBAPIKey).
+type TMDBProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewTMDBProvider construye un MetadataProvider respaldado por la API de
+// TMDB.
+func NewTMDBProvider(apiKey string, httpClient *http.Client) *TMDBProvider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &TMDBProvider{apiKey: apiKey, httpClient: httpClient, baseURL: tmdbBaseURL}
+}
+
+type tmdbShow struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Overview         string `json:"overview"`
+	PosterPath       string `json:"poster_path"`
+	FirstAirDate     string `json:"first_air_date"`
+	NumberOfEpisodes int    `json:"number_of_episodes"`
+}
+
+type tmdbSearchResponse struct {
+	Results []tmdbShow `json:"results"`
+}
+
+func (p *TMDBProvider) Search(ctx context.Context, query string) ([]Result, error) {
+	reqURL := fmt.Sprintf("%s/search/tv?api_key=%s&query=%s", p.baseURL, p.apiKey, url.QueryEscape(query))
+
+	var response tmdbSearchResponse
+	if err := p.getJSON(ctx, reqURL, &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(response.Results))
+	for _, show := range response.Results {
+		results = append(results, p.toResult(show))
+	}
+	return results, nil
+}
+
+func (p *TMDBProvider) Fetch(ctx context.Context, externalID string) (Result, error) {
+	reqURL := fmt.Sprintf("%s/tv/%s?api_key=%s", p.baseURL, externalID, p.apiKey)
+
+	var show tmdbShow
+	if err := p.getJSON(ctx, reqURL, &show); err != nil {
+		return Result{}, err
+	}
+
+	return p.toResult(show), nil
+}
+
+func (p *TMDBProvider) toResult(show tmdbShow) Result {
+	result := Result{
+		ExternalID:    strconv.Itoa(show.ID),
+		Title:         show.Name,
+		Description:   show.Overview,
+		TotalEpisodes: show.NumberOfEpisodes,
+		AirDate:       show.FirstAirDate,
+	}
+	if show.PosterPath != "" {
+		result.ThumbnailURL = tmdbImageBaseURL + show.PosterPath
+	}
+	return result
+}
+
+func (p *TMDBProvider) getJSON(ctx context.Context, reqURL string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tmdb: unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
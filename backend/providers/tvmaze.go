@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const tvMazeBaseURL = "https://api.tvmaze.com"
+
+// TVMazeProvider busca y obtiene series en el catálogo público de TVMaze.
+type TVMazeProvider struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewTVMazeProvider construye un MetadataProvider respaldado por la API de
+// TVMaze.
+func NewTVMazeProvider(httpClient *http.Client) *TVMazeProvider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &TVMazeProvider{httpClient: httpClient, baseURL: tvMazeBaseURL}
+}
+
+type tvMazeShow struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Summary   string `json:"summary"`
+	Premiered string `json:"premiered"`
+	Image     *struct {
+		Original string `json:"original"`
+	} `json:"image"`
+	Embedded *struct {
+		Episodes []struct{} `json:"episodes"`
+	} `json:"_embedded"`
+}
+
+func (p *TVMazeProvider) Search(ctx context.Context, query string) ([]Result, error) {
+	reqURL := fmt.Sprintf("%s/search/shows?q=%s", p.baseURL, url.QueryEscape(query))
+
+	var matches []struct {
+		Show tvMazeShow `json:"show"`
+	}
+	if err := p.getJSON(ctx, reqURL, &matches); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, toResult(m.Show))
+	}
+	return results, nil
+}
+
+func (p *TVMazeProvider) Fetch(ctx context.Context, externalID string) (Result, error) {
+	reqURL := fmt.Sprintf("%s/shows/%s?embed=episodes", p.baseURL, externalID)
+
+	var show tvMazeShow
+	if err := p.getJSON(ctx, reqURL, &show); err != nil {
+		return Result{}, err
+	}
+
+	result := toResult(show)
+	if show.Embedded != nil {
+		result.TotalEpisodes = len(show.Embedded.Episodes)
+	}
+	return result, nil
+}
+
+func toResult(show tvMazeShow) Result {
+	result := Result{
+		ExternalID:  strconv.Itoa(show.ID),
+		Title:       show.Name,
+		Description: show.Summary,
+		AirDate:     show.Premiered,
+	}
+	if show.Image != nil {
+		result.ThumbnailURL = show.Image.Original
+	}
+	return result
+}
+
+func (p *TVMazeProvider) getJSON(ctx context.Context, reqURL string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tvmaze: unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
@@ -0,0 +1,270 @@
+// Package repository aísla el acceso a datos detrás de interfaces, para que
+// los handlers dependan de un contrato en vez de una conexión sqlx concreta.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrNotFound indica que la serie solicitada no existe (o no pertenece al
+// usuario autenticado).
+var ErrNotFound = errors.New("series not found")
+
+// ErrLastEpisode indica que la serie ya está en su último episodio y no se
+// puede avanzar más.
+var ErrLastEpisode = errors.New("already at the last episode")
+
+// Series representa un registro del watchlist de un usuario.
+type Series struct {
+	ID             int       `json:"id" db:"id"`
+	UserID         int       `json:"user_id" db:"user_id"`
+	Title          string    `json:"title" db:"title" binding:"required"`
+	Description    string    `json:"description" db:"description"`
+	Status         string    `json:"status" db:"status" enums:"pending,watching,completed"`
+	CurrentEpisode int       `json:"current_episode" db:"current_episode"`
+	TotalEpisodes  int       `json:"total_episodes" db:"total_episodes"`
+	Score          int       `json:"score" db:"score"`
+	ThumbnailURL   string    `json:"thumbnail_url" db:"thumbnail_url"`
+	AirDate        string    `json:"air_date" db:"air_date"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ListOptions acota, ordena y filtra los resultados de List.
+type ListOptions struct {
+	Limit      int
+	Offset     int
+	SortColumn string
+	SortOrder  string
+	Status     string
+	MinScore   *int
+	TitleLike  string
+}
+
+// SortColumnWhitelist evita inyección SQL a través de ListOptions.SortColumn.
+var SortColumnWhitelist = map[string]bool{
+	"id":              true,
+	"title":           true,
+	"score":           true,
+	"current_episode": true,
+	"updated_at":      true,
+}
+
+// SeriesRepository abstrae el almacenamiento de series para que los handlers
+// no dependan de sqlx ni de Postgres directamente.
+type SeriesRepository interface {
+	List(ctx context.Context, userID int, opts ListOptions) ([]Series, int, error)
+	Get(ctx context.Context, id, userID int) (Series, error)
+	Create(ctx context.Context, s Series) (Series, error)
+	Update(ctx context.Context, id, userID int, s Series) error
+	Delete(ctx context.Context, id, userID int) error
+	SetStatus(ctx context.Context, id, userID int, status string) error
+	IncrementEpisode(ctx context.Context, id, userID int) error
+	// AdjustScore aplica (o retira, o invierte) el voto del usuario y
+	// devuelve el nuevo score agregado junto con el voto vigente del caller.
+	AdjustScore(ctx context.Context, id, userID, value int) (score, myVote int, err error)
+}
+
+type postgresSeriesRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresSeriesRepository construye un SeriesRepository respaldado por
+// Postgres a través de sqlx.
+func NewPostgresSeriesRepository(db *sqlx.DB) SeriesRepository {
+	return &postgresSeriesRepository{db: db}
+}
+
+func (r *postgresSeriesRepository) List(ctx context.Context, userID int, opts ListOptions) ([]Series, int, error) {
+	where := []string{"user_id = $1"}
+	args := []interface{}{userID}
+
+	if opts.Status != "" {
+		args = append(args, opts.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if opts.MinScore != nil {
+		args = append(args, *opts.MinScore)
+		where = append(where, fmt.Sprintf("score >= $%d", len(args)))
+	}
+
+	if opts.TitleLike != "" {
+		args = append(args, "%"+opts.TitleLike+"%")
+		where = append(where, fmt.Sprintf("title ILIKE $%d", len(args)))
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM series WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, opts.Limit, opts.Offset)
+	listQuery := fmt.Sprintf(
+		"SELECT * FROM series WHERE %s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		whereClause, opts.SortColumn, opts.SortOrder, len(args)-1, len(args),
+	)
+
+	var series []Series
+	if err := r.db.SelectContext(ctx, &series, listQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return series, total, nil
+}
+
+func (r *postgresSeriesRepository) Get(ctx context.Context, id, userID int) (Series, error) {
+	var s Series
+	err := r.db.GetContext(ctx, &s, "SELECT * FROM series WHERE id = $1 AND user_id = $2", id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Series{}, ErrNotFound
+	}
+	return s, err
+}
+
+func (r *postgresSeriesRepository) Create(ctx context.Context, s Series) (Series, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO series (user_id, title, description, status, current_episode, total_episodes, score, thumbnail_url, air_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, s.UserID, s.Title, s.Description, s.Status, s.CurrentEpisode, s.TotalEpisodes, s.Score, s.ThumbnailURL, s.AirDate).Scan(&s.ID)
+	return s, err
+}
+
+// Update no escribe la columna score: el único camino para modificarla es
+// AdjustScore, para que un cliente no pueda manipularla vía PUT.
+func (r *postgresSeriesRepository) Update(ctx context.Context, id, userID int, s Series) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE series
+		SET title = $1, description = $2, status = $3,
+			current_episode = $4, total_episodes = $5,
+			thumbnail_url = $6, air_date = $7
+		WHERE id = $8 AND user_id = $9
+	`, s.Title, s.Description, s.Status, s.CurrentEpisode, s.TotalEpisodes, s.ThumbnailURL, s.AirDate, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireRowsAffected(result)
+}
+
+func (r *postgresSeriesRepository) Delete(ctx context.Context, id, userID int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM series WHERE id = $1 AND user_id = $2", id, userID)
+	if err != nil {
+		return err
+	}
+	return requireRowsAffected(result)
+}
+
+func (r *postgresSeriesRepository) SetStatus(ctx context.Context, id, userID int, status string) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE series SET status = $1 WHERE id = $2 AND user_id = $3", status, id, userID)
+	if err != nil {
+		return err
+	}
+	return requireRowsAffected(result)
+}
+
+func (r *postgresSeriesRepository) IncrementEpisode(ctx context.Context, id, userID int) error {
+	var current, total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT current_episode, total_episodes
+		FROM series WHERE id = $1 AND user_id = $2
+	`, id, userID).Scan(&current, &total)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if current >= total {
+		return ErrLastEpisode
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE series
+		SET current_episode = current_episode + 1
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	return err
+}
+
+func (r *postgresSeriesRepository) AdjustScore(ctx context.Context, id, userID, value int) (int, int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	var seriesExists bool
+	if err := tx.GetContext(ctx, &seriesExists, "SELECT EXISTS(SELECT 1 FROM series WHERE id = $1 AND user_id = $2)", id, userID); err != nil {
+		return 0, 0, err
+	}
+	if !seriesExists {
+		return 0, 0, ErrNotFound
+	}
+
+	var existingVote sql.NullInt16
+	err = tx.GetContext(ctx, &existingVote, "SELECT value FROM series_votes WHERE user_id = $1 AND series_id = $2", userID, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, err
+	}
+
+	currentVote := 0
+	if existingVote.Valid {
+		currentVote = int(existingVote.Int16)
+	}
+
+	if currentVote == value {
+		// Repetir el mismo voto lo retira.
+		if _, err := tx.ExecContext(ctx, "DELETE FROM series_votes WHERE user_id = $1 AND series_id = $2", userID, id); err != nil {
+			return 0, 0, err
+		}
+	} else {
+		// Voto nuevo o invertido.
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO series_votes (user_id, series_id, value)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, series_id) DO UPDATE SET value = $3
+		`, userID, id, value); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	var newScore int
+	if err := tx.GetContext(ctx, &newScore, "SELECT COALESCE(SUM(value), 0) FROM series_votes WHERE series_id = $1", id); err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE series SET score = $1 WHERE id = $2", newScore, id); err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+
+	myVote := value
+	if currentVote == value {
+		myVote = 0
+	}
+
+	return newScore, myVote, nil
+}
+
+func requireRowsAffected(result sql.Result) error {
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}